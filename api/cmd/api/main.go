@@ -1,26 +1,95 @@
 package main
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/sf1tzp/symbology/api/internal/core/server"
+	"github.com/sf1tzp/symbology/api/internal/debug"
 	"github.com/sf1tzp/symbology/api/internal/handlers"
+	"github.com/sf1tzp/symbology/api/internal/middleware"
 )
 
+const defaultRequestTimeout = 30 * time.Second
+
 func main() {
-	port := os.Getenv("API_PORT")
-	if port == "" {
-		port = "8080"
+	cfg, err := server.LoadConf(os.Getenv("API_CONFIG_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
 	}
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("GET /health", handlers.Health)
+	mux.HandleFunc("/health", handlers.Method(http.MethodGet, handlers.Health))
+
+	logger := log.Default()
+	chain := middleware.Chain(
+		middleware.RequestID,
+		middleware.AccessLog(logger),
+		middleware.Timeout(defaultRequestTimeout),
+		middleware.Recover(logger),
+	)
+
+	go func() {
+		if err := debug.ListenAndServe(debugAddr()); err != nil {
+			log.Printf("Debug server failed: %v", err)
+		}
+	}()
+
+	srv := server.New(chain(mux), server.Config{
+		Addr:              cfg.Addr,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		TLS:               tlsConfig(cfg),
+	})
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	errc := srv.Start()
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			log.Fatalf("Server failed: %v", err)
+		}
+		return
+	case sig := <-sigCh:
+		log.Printf("Received signal %s, stopping server", sig)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Stop(shutdownCtx); err != nil {
+		log.Fatalf("Server shutdown failed: %v", err)
+	}
+}
+
+// tlsConfig returns nil unless cfg configures an HTTPS listener, since
+// server.Config treats a nil TLS field as "no HTTPS listener".
+func tlsConfig(cfg server.Conf) *server.TLSConfig {
+	if cfg.TLSAddr == "" {
+		return nil
+	}
+	return &server.TLSConfig{
+		Addr:     cfg.TLSAddr,
+		CertFile: cfg.TLSCertFile,
+		KeyFile:  cfg.TLSKeyFile,
+	}
+}
 
-	addr := fmt.Sprintf(":%s", port)
-	log.Printf("Starting API server on %s", addr)
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		log.Fatalf("Server failed: %v", err)
+func debugAddr() string {
+	addr, ok := os.LookupEnv("DEBUG_ADDR")
+	if !ok {
+		return "localhost:6060"
 	}
+	return addr
 }
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Conf holds the settings main needs to build a Server, sourced from
+// environment variables and, optionally, a YAML config file. Env vars take
+// precedence over the file, so a file can hold shared defaults while an
+// environment overrides what it needs to.
+type Conf struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownTimeout   time.Duration
+
+	TLSAddr     string
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// defaultConf mirrors the timeouts net/http recommends for internet-facing
+// servers.
+func defaultConf() Conf {
+	return Conf{
+		Addr:              ":8080",
+		ReadTimeout:       5 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		ShutdownTimeout:   15 * time.Second,
+	}
+}
+
+// LoadConf builds a Conf starting from defaultConf, overlaying file (a
+// simple "key: value" per line YAML subset; empty path skips this step),
+// then overlaying recognized API_* environment variables.
+func LoadConf(file string) (Conf, error) {
+	cfg := defaultConf()
+
+	if file != "" {
+		if err := overlayFile(&cfg, file); err != nil {
+			return Conf{}, fmt.Errorf("load config file %s: %w", file, err)
+		}
+	}
+
+	if err := overlayEnv(&cfg); err != nil {
+		return Conf{}, err
+	}
+
+	return cfg, nil
+}
+
+func overlayFile(cfg *Conf, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		if err := setField(cfg, key, value); err != nil {
+			return fmt.Errorf("line %q: %w", line, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func overlayEnv(cfg *Conf) error {
+	for _, key := range []string{
+		"addr", "read_timeout", "read_header_timeout", "write_timeout",
+		"idle_timeout", "max_header_bytes", "shutdown_timeout",
+		"tls_addr", "tls_cert_file", "tls_key_file",
+	} {
+		envKey := "API_" + strings.ToUpper(key)
+		if value, ok := os.LookupEnv(envKey); ok {
+			if err := setField(cfg, key, value); err != nil {
+				return fmt.Errorf("invalid %s: %w", envKey, err)
+			}
+		}
+	}
+	return nil
+}
+
+func setField(cfg *Conf, key, value string) error {
+	switch key {
+	case "addr":
+		cfg.Addr = value
+	case "read_timeout":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.ReadTimeout = d
+	case "read_header_timeout":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.ReadHeaderTimeout = d
+	case "write_timeout":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.WriteTimeout = d
+	case "idle_timeout":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.IdleTimeout = d
+	case "shutdown_timeout":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.ShutdownTimeout = d
+	case "max_header_bytes":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		cfg.MaxHeaderBytes = n
+	case "tls_addr":
+		cfg.TLSAddr = value
+	case "tls_cert_file":
+		cfg.TLSCertFile = value
+	case "tls_key_file":
+		cfg.TLSKeyFile = value
+	default:
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	return nil
+}
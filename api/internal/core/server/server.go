@@ -0,0 +1,130 @@
+// Package server owns the API's HTTP/HTTPS listener lifecycle: building the
+// listeners from a Config, starting them, and shutting them down together on
+// request. main is expected to do little beyond load a Conf, build a
+// router, construct a Server, and call Start/Stop around a signal wait.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config describes the listeners a Server should run.
+type Config struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+
+	// TLS, if non-nil, adds a second HTTPS listener alongside the plain
+	// HTTP one.
+	TLS *TLSConfig
+}
+
+// Server owns an HTTP listener and an optional HTTPS listener, built from
+// the same Config and handler, started and stopped together.
+type Server struct {
+	cfg   Config
+	http  *http.Server
+	https *http.Server
+}
+
+// New builds a Server for handler from cfg. It does not start listening;
+// call Start for that.
+func New(handler http.Handler, cfg Config) *Server {
+	s := &Server{
+		cfg: cfg,
+		http: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		},
+	}
+
+	if cfg.TLS != nil {
+		s.https = &http.Server{
+			Addr:              cfg.TLS.Addr,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+			TLSConfig:         cfg.TLS.tlsConfig(),
+		}
+	}
+
+	return s
+}
+
+// Start runs each configured listener in its own goroutine and returns a
+// channel on which each listener sends its terminal error (nil on a clean
+// shutdown via Stop) exactly once. The channel is sized for every listener,
+// so a caller that only reads the first value will never block a listener's
+// goroutine from exiting. Shutdown is driven entirely through Stop, not a
+// context passed here.
+func (s *Server) Start() <-chan error {
+	errc := make(chan error, 2)
+
+	go func() {
+		errc <- runListener("http", s.http.Addr, s.http.ListenAndServe)
+	}()
+
+	if s.https != nil {
+		go func() {
+			errc <- runListener("https", s.https.Addr, func() error {
+				return s.https.ListenAndServeTLS(s.cfg.TLS.CertFile, s.cfg.TLS.KeyFile)
+			})
+		}()
+	}
+
+	return errc
+}
+
+// Stop shuts down both listeners in parallel, waiting up to ctx's deadline
+// for in-flight requests to finish. It returns a joined error if either
+// listener fails to shut down cleanly.
+func (s *Server) Stop(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := s.http.Shutdown(ctx); err != nil {
+			errs[0] = fmt.Errorf("http shutdown: %w", err)
+		}
+	}()
+
+	if s.https != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.https.Shutdown(ctx); err != nil {
+				errs[1] = fmt.Errorf("https shutdown: %w", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func runListener(name, addr string, listen func() error) error {
+	log.Printf("Starting %s server on %s", name, addr)
+	if err := listen(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServerStartStop(t *testing.T) {
+	srv := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), Config{Addr: "127.0.0.1:0"})
+
+	errc := srv.Start()
+
+	select {
+	case err := <-errc:
+		t.Fatalf("server exited before Stop was called: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := srv.Stop(ctx); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("listener goroutine reported error after Stop: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("listener goroutine did not exit after Stop")
+	}
+}
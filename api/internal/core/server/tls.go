@@ -0,0 +1,28 @@
+package server
+
+import "crypto/tls"
+
+// CertManager obtains a certificate on demand for a given ClientHello,
+// matching tls.Config.GetCertificate. golang.org/x/crypto/acme/autocert.Manager
+// satisfies this, so a CertManager can be swapped in without this package
+// depending on it directly.
+type CertManager interface {
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+}
+
+// TLSConfig configures a Server's HTTPS listener. Either set CertFile/KeyFile
+// for a static certificate, or set CertManager to obtain certificates on
+// demand; CertManager takes precedence if both are set.
+type TLSConfig struct {
+	Addr        string
+	CertFile    string
+	KeyFile     string
+	CertManager CertManager
+}
+
+func (t *TLSConfig) tlsConfig() *tls.Config {
+	if t.CertManager == nil {
+		return nil
+	}
+	return &tls.Config{GetCertificate: t.CertManager.GetCertificate}
+}
@@ -0,0 +1,88 @@
+// Package debug serves operational endpoints (pprof, expvar, build info,
+// liveness/readiness) on a private mux, kept separate from the public API so
+// they can never be reached through it.
+package debug
+
+import (
+	"expvar"
+	"log"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/sf1tzp/symbology/api/internal/handlers"
+)
+
+// Version, Commit, and BuildTime are injected at link time via -ldflags, e.g.
+//
+//	go build -ldflags "-X .../debug.Version=1.2.3 -X .../debug.Commit=$(git rev-parse HEAD)"
+var (
+	Version   = "dev"
+	Commit    = "none"
+	BuildTime = "unknown"
+)
+
+// ReadyCheck reports an error if a dependency the API relies on (database,
+// cache, ...) is not reachable. The /ready endpoint fails if any check fails.
+type ReadyCheck func() error
+
+func init() {
+	// Importing net/http/pprof and expvar registers their handlers on
+	// http.DefaultServeMux as a side effect of their init funcs. We mount
+	// them on our own mux below instead, so replace the default to make
+	// sure nothing can reach them through it.
+	http.DefaultServeMux = http.NewServeMux()
+}
+
+// NewMux builds the debug mux: pprof, expvar, build info, and liveness
+// ("/health") and readiness ("/ready", gated on checks) probes. It must never
+// be wired into the public API mux.
+func NewMux(checks ...ReadyCheck) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	mux.HandleFunc("/version", handlers.Method(http.MethodGet, versionHandler))
+	mux.HandleFunc("/health", handlers.Method(http.MethodGet, handlers.Health))
+	mux.HandleFunc("/ready", handlers.Method(http.MethodGet, readyHandler(checks)))
+
+	return mux
+}
+
+// ListenAndServe starts the debug server on addr and blocks until it exits.
+// If addr is empty, the debug server is disabled and ListenAndServe returns
+// nil immediately.
+func ListenAndServe(addr string, checks ...ReadyCheck) error {
+	if addr == "" {
+		return nil
+	}
+
+	log.Printf("Starting debug server on %s", addr)
+	return http.ListenAndServe(addr, NewMux(checks...))
+}
+
+func versionHandler(w http.ResponseWriter, r *http.Request) {
+	handlers.JSON(w, http.StatusOK, map[string]string{
+		"version":    Version,
+		"commit":     Commit,
+		"build_time": BuildTime,
+	})
+}
+
+// readyHandler reports readiness: unlike /health, it runs checks against the
+// API's dependencies and fails if any of them do.
+func readyHandler(checks []ReadyCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, check := range checks {
+			if err := check(); err != nil {
+				handlers.Error(w, http.StatusServiceUnavailable, "not ready", err)
+				return
+			}
+		}
+		handlers.Message(w, http.StatusOK, "ready")
+	}
+}
@@ -0,0 +1,9 @@
+package handlers
+
+import "net/http"
+
+// Health reports that the API process is up. It does no dependency checks;
+// it exists so load balancers and orchestrators have a cheap liveness probe.
+func Health(w http.ResponseWriter, r *http.Request) {
+	Message(w, http.StatusOK, "ok")
+}
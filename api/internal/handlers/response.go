@@ -0,0 +1,52 @@
+// Package handlers contains the HTTP handlers for the symbology API.
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// envelope is the standard JSON body shape returned by every handler in this
+// package, success or failure, so clients only need to parse one schema.
+type envelope struct {
+	Message string   `json:"message,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	Details []string `json:"details,omitempty"`
+}
+
+// JSON writes v to w as a JSON body with the given status code. If v fails to
+// marshal, it falls back to a minimal hand-built error body rather than
+// risking a partially written or empty response.
+func JSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("handlers: failed to marshal JSON response: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"failed to marshal response"}`))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// Message writes a successful JSON envelope with the given status and
+// message, optionally attaching human-readable details.
+func Message(w http.ResponseWriter, status int, msg string, details ...string) {
+	JSON(w, status, envelope{Message: msg, Details: details})
+}
+
+// Error writes a JSON error envelope with the given status and message. If
+// err is non-nil its message is included in the body and logged server-side;
+// callers should not also write a response after calling Error.
+func Error(w http.ResponseWriter, status int, msg string, err error, details ...string) {
+	env := envelope{Message: msg, Details: details}
+	if err != nil {
+		log.Printf("handlers: %s: %v", msg, err)
+		env.Error = err.Error()
+	}
+	JSON(w, status, env)
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import "net/http"
+
+// Method wraps h so it only runs for requests using method, responding with
+// a 405 via the standard JSON envelope otherwise. It exists so routes can be
+// dispatched by method on a plain http.ServeMux without relying on Go's
+// method-prefixed patterns ("GET /health"), which require the module's go
+// directive to be 1.22 or newer.
+func Method(method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			Message(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		h(w, r)
+	}
+}
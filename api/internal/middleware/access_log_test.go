@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeLogger struct {
+	lines []string
+}
+
+func (f *fakeLogger) Printf(format string, v ...any) {
+	f.lines = append(f.lines, fmt.Sprintf(format, v...))
+}
+
+func TestAccessLog(t *testing.T) {
+	logger := &fakeLogger{}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("short and stout"))
+	})
+
+	h := AccessLog(logger)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/brew", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if len(logger.lines) != 1 {
+		t.Fatalf("expected exactly one log line, got %d: %v", len(logger.lines), logger.lines)
+	}
+
+	line := logger.lines[0]
+	for _, want := range []string{
+		"method=GET",
+		"path=/brew",
+		fmt.Sprintf("status=%d", http.StatusTeapot),
+		"bytes=15",
+	} {
+		if !strings.Contains(line, want) {
+			t.Errorf("log line %q missing %q", line, want)
+		}
+	}
+}
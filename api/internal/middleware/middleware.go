@@ -0,0 +1,41 @@
+// Package middleware provides composable http.Handler wrappers applied
+// around the API mux: request ID propagation, access logging, panic
+// recovery, and per-request timeouts.
+package middleware
+
+import "net/http"
+
+// Chain composes middleware so the first one listed runs outermost, i.e.
+// Chain(a, b)(h) is equivalent to a(b(h)).
+func Chain(mws ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			h = mws[i](h)
+		}
+		return h
+	}
+}
+
+// responseWriter wraps http.ResponseWriter to capture the status code and
+// number of bytes written, so middleware further out in the chain (access
+// logging) can report on what an inner handler actually did.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (rw *responseWriter) WriteHeader(status int) {
+	rw.status = status
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += n
+	return n, err
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/sf1tzp/symbology/api/internal/handlers"
+)
+
+// Recover returns middleware that catches a panic in an inner handler, logs
+// it with a stack trace, and responds with a 500 via the handlers package's
+// JSON error envelope instead of letting net/http close the connection.
+func Recover(logger Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if rec := recover(); rec != nil {
+					logger.Printf("panic: %v\n%s", rec, debug.Stack())
+					handlers.Error(w, http.StatusInternalServerError, "internal server error", fmt.Errorf("%v", rec))
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecoverThroughFullChain(t *testing.T) {
+	logger := &fakeLogger{}
+	panics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	chain := Chain(
+		RequestID,
+		AccessLog(logger),
+		Timeout(time.Second),
+		Recover(logger),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/explode", nil)
+	rec := httptest.NewRecorder()
+
+	// If Recover doesn't run in the same goroutine the handler panics in
+	// (Timeout's handler goroutine), nothing recovers this panic and it
+	// crashes the test binary instead of being caught.
+	chain(panics).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	if !strings.Contains(rec.Body.String(), "boom") {
+		t.Errorf("response body %q does not mention the panic value", rec.Body.String())
+	}
+
+	found := false
+	for _, line := range logger.lines {
+		if strings.HasPrefix(line, "panic: boom") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a logged panic line, got %v", logger.lines)
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// RequestIDHeader is the header checked for an incoming request ID and set
+// on every response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds how much of an incoming X-Request-ID we trust, so a
+// client can't inflate log lines or headers with an oversized value.
+const maxRequestIDLen = 128
+
+// RequestID honors an incoming X-Request-ID header or generates a ULID,
+// stores it in the request context, and echoes it on the response header so
+// callers can correlate logs across a request's lifetime. An incoming value
+// is only honored if it is a reasonably-sized, single-line token; anything
+// else is replaced with a generated ULID rather than trusted verbatim, since
+// it flows unescaped into access log lines.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newULID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID stored by RequestID, or "" if
+// none is present.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// validRequestID reports whether id is short enough and free of control
+// characters to be safely echoed into a header and a log line.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
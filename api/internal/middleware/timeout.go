@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sf1tzp/symbology/api/internal/handlers"
+)
+
+// Timeout returns middleware that cancels a request's context after d and, if
+// the handler has not begun writing a response by then, responds with a 503
+// via the handlers package's standard JSON envelope. If the handler has
+// already written its header, no 503 is sent and the handler's response
+// stands. http.TimeoutHandler does the same cancellation but
+// writes a plain-text body, which would give timed-out requests a different
+// response shape than every other error path in this API.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w}
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+
+			select {
+			case <-done:
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				if !tw.wroteHeader {
+					tw.timedOut = true
+					handlers.Error(w, http.StatusServiceUnavailable, "request timed out", ctx.Err())
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter wraps http.ResponseWriter so that once Timeout has written
+// the timeout response, a handler goroutine that is still running (and may
+// not know it lost the race) can no longer also write to the connection.
+type timeoutWriter struct {
+	http.ResponseWriter
+	mu          sync.Mutex
+	timedOut    bool
+	wroteHeader bool
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	timedOut := tw.timedOut
+	wroteHeader := tw.wroteHeader
+	tw.wroteHeader = true
+	tw.mu.Unlock()
+
+	if timedOut {
+		return len(b), nil
+	}
+	if !wroteHeader {
+		tw.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	return tw.ResponseWriter.Write(b)
+}
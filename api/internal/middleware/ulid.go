@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet ULIDs are encoded with: it
+// excludes I, L, O, and U to avoid confusion with 1 and 0.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID generates a ULID: a 48-bit millisecond timestamp followed by 80
+// bits of randomness, encoded as 26 Crockford base32 characters. ULIDs sort
+// lexicographically by creation time, which makes them easier to correlate
+// in logs than an opaque UUID. It falls back to "unknown" in the
+// vanishingly unlikely case the system CSPRNG fails, rather than panicking
+// mid-request.
+func newULID() string {
+	var entropy [10]byte
+	if _, err := rand.Read(entropy[:]); err != nil {
+		return "unknown"
+	}
+
+	var data [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	data[0] = byte(ms >> 40)
+	data[1] = byte(ms >> 32)
+	data[2] = byte(ms >> 24)
+	data[3] = byte(ms >> 16)
+	data[4] = byte(ms >> 8)
+	data[5] = byte(ms)
+	copy(data[6:], entropy[:])
+
+	return encodeCrockford(data)
+}
+
+func encodeCrockford(data [16]byte) string {
+	out := make([]byte, 26)
+	out[0] = crockford[(data[0]&224)>>5]
+	out[1] = crockford[data[0]&31]
+	out[2] = crockford[(data[1]&248)>>3]
+	out[3] = crockford[((data[1]&7)<<2)|((data[2]&192)>>6)]
+	out[4] = crockford[(data[2]&62)>>1]
+	out[5] = crockford[((data[2]&1)<<4)|((data[3]&240)>>4)]
+	out[6] = crockford[((data[3]&15)<<1)|((data[4]&128)>>7)]
+	out[7] = crockford[(data[4]&124)>>2]
+	out[8] = crockford[((data[4]&3)<<3)|((data[5]&224)>>5)]
+	out[9] = crockford[data[5]&31]
+	out[10] = crockford[(data[6]&248)>>3]
+	out[11] = crockford[((data[6]&7)<<2)|((data[7]&192)>>6)]
+	out[12] = crockford[(data[7]&62)>>1]
+	out[13] = crockford[((data[7]&1)<<4)|((data[8]&240)>>4)]
+	out[14] = crockford[((data[8]&15)<<1)|((data[9]&128)>>7)]
+	out[15] = crockford[(data[9]&124)>>2]
+	out[16] = crockford[((data[9]&3)<<3)|((data[10]&224)>>5)]
+	out[17] = crockford[data[10]&31]
+	out[18] = crockford[(data[11]&248)>>3]
+	out[19] = crockford[((data[11]&7)<<2)|((data[12]&192)>>6)]
+	out[20] = crockford[(data[12]&62)>>1]
+	out[21] = crockford[((data[12]&1)<<4)|((data[13]&240)>>4)]
+	out[22] = crockford[((data[13]&15)<<1)|((data[14]&128)>>7)]
+	out[23] = crockford[(data[14]&124)>>2]
+	out[24] = crockford[((data[14]&3)<<3)|((data[15]&224)>>5)]
+	out[25] = crockford[data[15]&31]
+	return string(out)
+}